@@ -0,0 +1,195 @@
+package rfc9839
+
+import (
+	"fmt"
+	"sort"
+	"unicode"
+)
+
+// NewSubset builds a Subset from caller-supplied [lo, hi] rune ranges,
+// inclusive on both ends. It's an error for any lo to exceed its hi, for
+// either to fall outside [0, 0x10FFFF], or for a range to include any of
+// the UTF-16 surrogates 0xD800-0xDFFF: every Subset in this package,
+// built-in or custom, is a set of Unicode scalar values, which by
+// definition excludes surrogates.
+//
+// By default ranges are sorted and coalesced so that subsetContains
+// doesn't do redundant work. If priority is given, it must be a
+// permutation of the indexes of pairs (0..len(pairs)-1) and instead fixes
+// the stored order to that priority, skipping the sort; this is the "hot
+// ranges first" hint that lets the linear subsetContains fallback used by
+// ValidRune and the ASCII bitmap builder short-circuit quickly, the same
+// trick the hand-ordered Scalars/XmlChars/Assignables tables below rely
+// on.
+func NewSubset(pairs [][2]rune, priority ...int) (*Subset, error) {
+	rps := make([]runePair, len(pairs))
+	for i, p := range pairs {
+		lo, hi := p[0], p[1]
+		if lo < 0 || hi > 0x10FFFF || lo > hi {
+			return nil, fmt.Errorf("rfc9839: invalid range [%#x, %#x]", lo, hi)
+		}
+		if lo <= 0xDFFF && hi >= 0xD800 {
+			return nil, fmt.Errorf("rfc9839: range [%#x, %#x] includes surrogate code points", lo, hi)
+		}
+		rps[i] = runePair{lo: lo, hi: hi}
+	}
+	if len(priority) == 0 {
+		return &Subset{pairs: coalesce(rps)}, nil
+	}
+	if len(priority) != len(rps) {
+		return nil, fmt.Errorf("rfc9839: priority must list all %d ranges exactly once", len(rps))
+	}
+	ordered := make([]runePair, len(rps))
+	seen := make([]bool, len(rps))
+	for i, idx := range priority {
+		if idx < 0 || idx >= len(rps) || seen[idx] {
+			return nil, fmt.Errorf("rfc9839: priority index %d is out of range or repeated", idx)
+		}
+		seen[idx] = true
+		ordered[i] = rps[idx]
+	}
+	return &Subset{pairs: ordered}, nil
+}
+
+// Union returns a Subset containing every rune in a or b.
+func Union(a, b *Subset) *Subset {
+	combined := append(append([]runePair(nil), a.pairs...), b.pairs...)
+	return &Subset{pairs: coalesce(combined)}
+}
+
+// Intersect returns a Subset containing only runes in both a and b.
+func Intersect(a, b *Subset) *Subset {
+	as, bs := sortedCopy(a.pairs), sortedCopy(b.pairs)
+	var out []runePair
+	i, j := 0, 0
+	for i < len(as) && j < len(bs) {
+		lo, hi := as[i].lo, as[i].hi
+		if bs[j].lo > lo {
+			lo = bs[j].lo
+		}
+		if bs[j].hi < hi {
+			hi = bs[j].hi
+		}
+		if lo <= hi {
+			out = append(out, runePair{lo, hi})
+		}
+		if as[i].hi < bs[j].hi {
+			i++
+		} else {
+			j++
+		}
+	}
+	return &Subset{pairs: coalesce(out)}
+}
+
+// Subtract returns a Subset containing the runes in a that are not in b.
+func Subtract(a, b *Subset) *Subset {
+	as, bs := sortedCopy(a.pairs), sortedCopy(b.pairs)
+	var out []runePair
+	for _, ap := range as {
+		cur := ap.lo
+		for _, bp := range bs {
+			if bp.hi < cur || bp.lo > ap.hi {
+				continue
+			}
+			if bp.lo > cur {
+				out = append(out, runePair{cur, bp.lo - 1})
+			}
+			if bp.hi+1 > cur {
+				cur = bp.hi + 1
+			}
+		}
+		if cur <= ap.hi {
+			out = append(out, runePair{cur, ap.hi})
+		}
+	}
+	return &Subset{pairs: coalesce(out)}
+}
+
+// RangeTable converts sub to a *unicode.RangeTable, usable anywhere the
+// standard library's unicode package wants one, e.g. unicode.Is. The
+// pairs are coalesced before conversion regardless of how sub was built
+// (including via NewSubset's priority ordering), since unicode.RangeTable
+// requires its R16/R32 entries to be sorted and non-overlapping - a
+// priority-ordered Subset would otherwise produce a table that silently
+// drops runes under unicode.Is's binary search.
+func (sub *Subset) RangeTable() *unicode.RangeTable {
+	rt := &unicode.RangeTable{}
+	for _, p := range coalesce(sub.pairs) {
+		switch {
+		case p.hi <= 0xFFFF:
+			rt.R16 = append(rt.R16, unicode.Range16{Lo: uint16(p.lo), Hi: uint16(p.hi), Stride: 1})
+		case p.lo > 0xFFFF:
+			rt.R32 = append(rt.R32, unicode.Range32{Lo: uint32(p.lo), Hi: uint32(p.hi), Stride: 1})
+		default:
+			rt.R16 = append(rt.R16, unicode.Range16{Lo: uint16(p.lo), Hi: 0xFFFF, Stride: 1})
+			rt.R32 = append(rt.R32, unicode.Range32{Lo: 0x10000, Hi: uint32(p.hi), Stride: 1})
+		}
+	}
+	for _, r16 := range rt.R16 {
+		if r16.Hi > unicode.MaxLatin1 {
+			break
+		}
+		rt.LatinOffset++
+	}
+	return rt
+}
+
+// FromRangeTable builds a Subset from a *unicode.RangeTable, so that
+// RFC 9839 subsets can be combined with existing unicode.RangeTables such
+// as unicode.C or a hand-built table of disallowed characters. Any
+// surrogate code points the table contains (e.g. via unicode.Cs) are
+// silently dropped, since every Subset in this package excludes them by
+// definition.
+func FromRangeTable(rt *unicode.RangeTable) *Subset {
+	var pairs []runePair
+	for _, r := range rt.R16 {
+		pairs = appendRangeTableEntry(pairs, rune(r.Lo), rune(r.Hi), rune(r.Stride))
+	}
+	for _, r := range rt.R32 {
+		pairs = appendRangeTableEntry(pairs, rune(r.Lo), rune(r.Hi), rune(r.Stride))
+	}
+	return Subtract(&Subset{pairs: coalesce(pairs)}, surrogates)
+}
+
+// surrogates is the UTF-16 surrogate range, used internally to keep
+// surrogates out of any Subset built from external input.
+var surrogates = &Subset{pairs: []runePair{{0xD800, 0xDFFF}}}
+
+func appendRangeTableEntry(pairs []runePair, lo, hi, stride rune) []runePair {
+	if stride <= 1 {
+		return append(pairs, runePair{lo, hi})
+	}
+	for r := lo; r <= hi; r += stride {
+		pairs = append(pairs, runePair{r, r})
+	}
+	return pairs
+}
+
+// sortedCopy returns pairs sorted by lo, leaving the original slice - which
+// may be kept in "guessed traffic order" for the linear scan - untouched.
+func sortedCopy(pairs []runePair) []runePair {
+	sp := append([]runePair(nil), pairs...)
+	sort.Slice(sp, func(i, j int) bool { return sp[i].lo < sp[j].lo })
+	return sp
+}
+
+// coalesce sorts pairs by lo and merges overlapping or adjacent ranges.
+func coalesce(pairs []runePair) []runePair {
+	if len(pairs) == 0 {
+		return nil
+	}
+	sp := sortedCopy(pairs)
+	out := sp[:1]
+	for _, p := range sp[1:] {
+		last := &out[len(out)-1]
+		if p.lo <= last.hi+1 {
+			if p.hi > last.hi {
+				last.hi = p.hi
+			}
+			continue
+		}
+		out = append(out, p)
+	}
+	return out
+}
@@ -0,0 +1,152 @@
+package rfc9839
+
+import (
+	"testing"
+	"unicode"
+)
+
+func TestNewSubsetCoalesces(t *testing.T) {
+	sub, err := NewSubset([][2]rune{{'a', 'm'}, {'g', 'z'}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(sub.pairs) != 1 || sub.pairs[0].lo != 'a' || sub.pairs[0].hi != 'z' {
+		t.Errorf("got %+v, want a single coalesced [a,z] range", sub.pairs)
+	}
+	if !sub.ValidRune('q') || sub.ValidRune('0') {
+		t.Error("coalesced subset doesn't behave as expected")
+	}
+}
+
+func TestNewSubsetRejectsBadRange(t *testing.T) {
+	if _, err := NewSubset([][2]rune{{'z', 'a'}}); err == nil {
+		t.Error("expected error for lo > hi")
+	}
+	if _, err := NewSubset([][2]rune{{0, 0x10FFFF + 1}}); err == nil {
+		t.Error("expected error for hi beyond 0x10FFFF")
+	}
+	if _, err := NewSubset([][2]rune{{-1, 10}}); err == nil {
+		t.Error("expected error for negative lo")
+	}
+}
+
+func TestNewSubsetRejectsSurrogates(t *testing.T) {
+	if _, err := NewSubset([][2]rune{{0xD000, 0xD900}}); err == nil {
+		t.Error("expected error for a range overlapping the surrogates")
+	}
+	if _, err := NewSubset([][2]rune{{0xD800, 0xDFFF}}); err == nil {
+		t.Error("expected error for the surrogate range itself")
+	}
+}
+
+func TestNewSubsetPriorityOrdering(t *testing.T) {
+	sub, err := NewSubset([][2]rune{{'a', 'b'}, {'y', 'z'}}, 1, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(sub.pairs) != 2 || sub.pairs[0].lo != 'y' || sub.pairs[1].lo != 'a' {
+		t.Errorf("priority order wasn't preserved: %+v", sub.pairs)
+	}
+}
+
+func TestNewSubsetPriorityValidation(t *testing.T) {
+	if _, err := NewSubset([][2]rune{{'a', 'b'}, {'y', 'z'}}, 0); err == nil {
+		t.Error("expected error when priority doesn't list every range")
+	}
+	if _, err := NewSubset([][2]rune{{'a', 'b'}, {'y', 'z'}}, 0, 0); err == nil {
+		t.Error("expected error for a repeated priority index")
+	}
+	if _, err := NewSubset([][2]rune{{'a', 'b'}, {'y', 'z'}}, 0, 5); err == nil {
+		t.Error("expected error for an out-of-range priority index")
+	}
+}
+
+func TestUnion(t *testing.T) {
+	a, _ := NewSubset([][2]rune{{'a', 'm'}})
+	b, _ := NewSubset([][2]rune{{'n', 'z'}})
+	u := Union(a, b)
+	if !u.ValidRune('a') || !u.ValidRune('z') || u.ValidRune('0') {
+		t.Error("union doesn't contain the expected runes")
+	}
+}
+
+func TestIntersect(t *testing.T) {
+	a, _ := NewSubset([][2]rune{{'a', 'm'}})
+	b, _ := NewSubset([][2]rune{{'g', 'z'}})
+	i := Intersect(a, b)
+	if !i.ValidRune('h') || i.ValidRune('a') || i.ValidRune('z') {
+		t.Error("intersect doesn't contain only the overlap")
+	}
+}
+
+func TestSubtract(t *testing.T) {
+	a, _ := NewSubset([][2]rune{{'a', 'z'}})
+	b, _ := NewSubset([][2]rune{{'g', 'm'}})
+	d := Subtract(a, b)
+	if d.ValidRune('h') || !d.ValidRune('a') || !d.ValidRune('z') {
+		t.Error("subtract didn't remove just the given range")
+	}
+}
+
+func TestRangeTableRoundTrip(t *testing.T) {
+	rt := XmlChars.RangeTable()
+	for _, r16 := range rt.R16 {
+		if !unicode.Is(rt, rune(r16.Lo)) || !unicode.Is(rt, rune(r16.Hi)) {
+			t.Errorf("round-tripped range table rejects its own bounds %x-%x", r16.Lo, r16.Hi)
+		}
+	}
+	if !unicode.Is(rt, 'a') {
+		t.Error("expected 'a' to be in the XmlChars range table")
+	}
+	if unicode.Is(rt, 0) {
+		t.Error("expected NUL to be excluded from the XmlChars range table")
+	}
+}
+
+func TestFromRangeTable(t *testing.T) {
+	sub := FromRangeTable(unicode.Latin)
+	if !sub.ValidRune('Q') {
+		t.Error("expected a Latin letter to be in the converted subset")
+	}
+	if sub.ValidRune('0') {
+		t.Error("expected a digit to be excluded from the Latin-only subset")
+	}
+}
+
+func TestRangeTableCoalescesPriorityOrderedSubset(t *testing.T) {
+	sub, err := NewSubset([][2]rune{{10, 50}, {20, 25}}, 0, 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	rt := sub.RangeTable()
+	if !unicode.Is(rt, 45) {
+		t.Error("expected 45 to be covered by the overlapping ranges, even priority-ordered")
+	}
+	if sub.ValidRune(45) != unicode.Is(rt, 45) {
+		t.Error("RangeTable() disagrees with ValidRune for a priority-ordered Subset")
+	}
+}
+
+func TestRangeTableLatinOffset(t *testing.T) {
+	sub, err := NewSubset([][2]rune{{0x41, 0xFF}, {0x105, 0x200}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	rt := sub.RangeTable()
+	if rt.LatinOffset != 1 {
+		t.Errorf("LatinOffset = %d, want 1 for a single range ending at MaxLatin1", rt.LatinOffset)
+	}
+}
+
+func TestFromRangeTableExcludesSurrogates(t *testing.T) {
+	rt := &unicode.RangeTable{
+		R16: []unicode.Range16{{Lo: 0xD000, Hi: 0xE000, Stride: 1}},
+	}
+	sub := FromRangeTable(rt)
+	if sub.ValidRune(0xD900) {
+		t.Error("expected surrogates to be excluded from a subset built from a RangeTable")
+	}
+	if !sub.ValidRune(0xD100) || !sub.ValidRune(0xE000) {
+		t.Error("non-surrogate bounds of the range should survive")
+	}
+}
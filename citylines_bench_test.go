@@ -23,6 +23,24 @@ func BenchmarkCityLots(b *testing.B) {
 	}
 }
 
+// BenchmarkCityLotsPrevious runs the pre-fast-path linear scan over the
+// same citylots.jlines.gz lines, so it can be compared against
+// BenchmarkCityLots above.
+func BenchmarkCityLotsPrevious(b *testing.B) {
+	lines := getCityLotsLines(b)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		lineIndex := i
+		if i >= len(lines) {
+			lineIndex = 0
+		}
+		if !isUTF8InSubsetLinear(lines[lineIndex], Assignables) {
+			panic("OUCH!")
+		}
+	}
+}
+
 const oneMeg = 1024 * 1024
 
 var (
@@ -0,0 +1,126 @@
+package rfc9839
+
+import "unicode/utf8"
+
+// InvalidReason explains why FindFirstInvalid or FindFirstInvalidString
+// rejected a rune.
+type InvalidReason int
+
+const (
+	// ReasonMalformedUTF8 means the bytes at the offset aren't valid UTF-8
+	// at all, so there's no rune to report a subset violation for.
+	ReasonMalformedUTF8 InvalidReason = iota + 1
+	// ReasonSurrogate means the rune is in the UTF-16 surrogate range
+	// 0xD800-0xDFFF, which can't appear in valid UTF-8.
+	ReasonSurrogate
+	// ReasonNoncharacter means the rune is one of the 66 code points
+	// Unicode permanently reserves as "not a character".
+	ReasonNoncharacter
+	// ReasonControl means the rune is a C0 or C1 control character.
+	ReasonControl
+	// ReasonOutsideSubset means the rune is otherwise excluded by this
+	// particular Subset, even though none of the above apply.
+	ReasonOutsideSubset
+)
+
+func (reason InvalidReason) String() string {
+	switch reason {
+	case ReasonMalformedUTF8:
+		return "malformed UTF-8"
+	case ReasonSurrogate:
+		return "surrogate"
+	case ReasonNoncharacter:
+		return "noncharacter"
+	case ReasonControl:
+		return "control character"
+	case ReasonOutsideSubset:
+		return "outside subset"
+	default:
+		return "unknown"
+	}
+}
+
+// FindFirstInvalid scans u and reports the byte offset and rune of the
+// first byte sequence that keeps u out of sub, along with why it was
+// rejected. ok is false if u is entirely valid.
+func (sub *Subset) FindFirstInvalid(u []byte) (byteOffset int, r rune, reason InvalidReason, ok bool) {
+	index := 0
+	for index < len(u) {
+		decoded, width := utf8.DecodeRune(u[index:])
+		if decoded == 0xFFFD && width == 1 {
+			if sr, ok := decodeSurrogate(u[index:]); ok {
+				return index, sr, ReasonSurrogate, true
+			}
+			return index, decoded, ReasonMalformedUTF8, true
+		}
+		if !subsetContains(sub, decoded) {
+			return index, decoded, classifyReason(decoded), true
+		}
+		index += width
+	}
+	return 0, 0, 0, false
+}
+
+// FindFirstInvalidString is FindFirstInvalid for a string.
+func (sub *Subset) FindFirstInvalidString(s string) (byteOffset int, r rune, reason InvalidReason, ok bool) {
+	index := 0
+	for index < len(s) {
+		decoded, width := utf8.DecodeRuneInString(s[index:])
+		if decoded == 0xFFFD && width == 1 {
+			if sr, ok := decodeSurrogate([]byte(s[index:])); ok {
+				return index, sr, ReasonSurrogate, true
+			}
+			return index, decoded, ReasonMalformedUTF8, true
+		}
+		if !subsetContains(sub, decoded) {
+			return index, decoded, classifyReason(decoded), true
+		}
+		index += width
+	}
+	return 0, 0, 0, false
+}
+
+// classifyReason explains why a well-formed rune that made it past
+// utf8.DecodeRune is still excluded from a subset. A surrogate never
+// reaches here: encoding one in UTF-8 is itself malformed, so
+// utf8.DecodeRune always reports it via the RuneError/width-1 path, which
+// FindFirstInvalid/FindFirstInvalidString check for (and resolve to
+// ReasonSurrogate via decodeSurrogate) before calling classifyReason.
+func classifyReason(r rune) InvalidReason {
+	switch {
+	case isNoncharacter(r):
+		return ReasonNoncharacter
+	case r <= 0x1F || (r >= 0x7F && r <= 0x9F):
+		return ReasonControl
+	default:
+		return ReasonOutsideSubset
+	}
+}
+
+// isNoncharacter reports whether r is one of Unicode's permanently
+// reserved noncharacters: the last two code points of each plane, plus
+// the block 0xFDD0-0xFDEF.
+func isNoncharacter(r rune) bool {
+	if r >= 0xFDD0 && r <= 0xFDEF {
+		return true
+	}
+	return r&0xFFFE == 0xFFFE
+}
+
+// decodeSurrogate reports whether u begins with the 3-byte CESU-8/WTF-8
+// style encoding of a UTF-16 surrogate (the pattern produced by naively
+// running UTF-8 encoding logic on a surrogate value instead of rejecting
+// it), returning the surrogate rune it encodes. This is what
+// utf8.DecodeRune reports as a generic malformed sequence; decoding it by
+// hand lets callers distinguish "surrogate" from other malformed input.
+func decodeSurrogate(u []byte) (r rune, ok bool) {
+	if len(u) < 3 {
+		return 0, false
+	}
+	b0, b1, b2 := u[0], u[1], u[2]
+	if b0 != 0xED || b1 < 0xA0 || b1 > 0xBF || b2 < 0x80 || b2 > 0xBF {
+		return 0, false
+	}
+	r = rune(b0&0x0F)<<12 | rune(b1&0x3F)<<6 | rune(b2&0x3F)
+	return r, true
+}
@@ -0,0 +1,66 @@
+package rfc9839
+
+import "testing"
+
+func TestFindFirstInvalidGood(t *testing.T) {
+	if _, _, _, ok := Assignables.FindFirstInvalid([]byte("hello")); ok {
+		t.Error("valid input reported as invalid")
+	}
+	if _, _, _, ok := Assignables.FindFirstInvalidString("hello"); ok {
+		t.Error("valid input reported as invalid")
+	}
+}
+
+func TestFindFirstInvalidControl(t *testing.T) {
+	off, r, reason, ok := Assignables.FindFirstInvalid([]byte("ab\x01cd"))
+	if !ok || off != 2 || r != 0x01 || reason != ReasonControl {
+		t.Errorf("got (%d, %#x, %v, %v), want (2, 0x1, ReasonControl, true)", off, r, reason, ok)
+	}
+}
+
+func TestFindFirstInvalidNoncharacter(t *testing.T) {
+	u := append([]byte("ab"), []byte(string(rune(0xFFFE)))...)
+	off, r, reason, ok := Assignables.FindFirstInvalid(u)
+	if !ok || off != 2 || r != 0xFFFE || reason != ReasonNoncharacter {
+		t.Errorf("got (%d, %#x, %v, %v), want (2, 0xfffe, ReasonNoncharacter, true)", off, r, reason, ok)
+	}
+}
+
+func TestFindFirstInvalidMalformed(t *testing.T) {
+	u := []byte{'a', 0xFF, 'b'}
+	off, _, reason, ok := Assignables.FindFirstInvalid(u)
+	if !ok || off != 1 || reason != ReasonMalformedUTF8 {
+		t.Errorf("got (%d, %v, %v), want (1, ReasonMalformedUTF8, true)", off, reason, ok)
+	}
+}
+
+func TestFindFirstInvalidSurrogate(t *testing.T) {
+	// 0xED 0xBA 0xAD is the CESU-8-style encoding of U+DEAD, a low
+	// surrogate. It's malformed UTF-8, but its reason should still come
+	// back as ReasonSurrogate, not the generic ReasonMalformedUTF8.
+	u := []byte{'a', 0xED, 0xBA, 0xAD, 'b'}
+	off, r, reason, ok := Assignables.FindFirstInvalid(u)
+	if !ok || off != 1 || r != 0xDEAD || reason != ReasonSurrogate {
+		t.Errorf("got (%d, %#x, %v, %v), want (1, 0xdead, ReasonSurrogate, true)", off, r, reason, ok)
+	}
+
+	off, r, reason, ok = Assignables.FindFirstInvalidString(string(u))
+	if !ok || off != 1 || r != 0xDEAD || reason != ReasonSurrogate {
+		t.Errorf("got (%d, %#x, %v, %v), want (1, 0xdead, ReasonSurrogate, true)", off, r, reason, ok)
+	}
+}
+
+func TestInvalidReasonString(t *testing.T) {
+	cases := map[InvalidReason]string{
+		ReasonMalformedUTF8: "malformed UTF-8",
+		ReasonSurrogate:     "surrogate",
+		ReasonNoncharacter:  "noncharacter",
+		ReasonControl:       "control character",
+		ReasonOutsideSubset: "outside subset",
+	}
+	for reason, want := range cases {
+		if got := reason.String(); got != want {
+			t.Errorf("%v.String() = %q, want %q", int(reason), got, want)
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package rfc9839
+
+import "unicode/utf8"
+
+// ValidUtf8Reverse is ValidUtf8, walking u from the end using
+// utf8.DecodeLastRune instead of from the start. It's useful to consumers
+// that only care about validity near the tail of a buffer - log tailers,
+// ring buffers, truncating an oversized field - where a forward scan would
+// do needless work.
+func (sub *Subset) ValidUtf8Reverse(u []byte) bool {
+	_, _, ok := sub.LastInvalid(u)
+	return !ok
+}
+
+// LastInvalid scans u from the end and reports the byte offset and rune of
+// the invalid sequence closest to the end, i.e. the first one it
+// encounters walking backwards. ok is false if u is entirely valid.
+// byteOffset is where that invalid sequence starts. Scanning stops as
+// soon as that sequence is found, so only the suffix u[byteOffset+width:]
+// - everything already walked and found valid - is guaranteed valid;
+// nothing is asserted about u[:byteOffset], which may hide an earlier
+// invalid sequence the scan never reached. Callers wanting a clean prefix
+// to truncate at should use ValidUtf8 (or FindFirstInvalid) on
+// u[:byteOffset] instead of assuming it's already safe.
+func (sub *Subset) LastInvalid(u []byte) (byteOffset int, r rune, ok bool) {
+	end := len(u)
+	for end > 0 {
+		decoded, width := utf8.DecodeLastRune(u[:end])
+		if decoded == 0xFFFD && width == 1 {
+			return end - width, decoded, true
+		}
+		if !subsetContains(sub, decoded) {
+			return end - width, decoded, true
+		}
+		end -= width
+	}
+	return 0, 0, false
+}
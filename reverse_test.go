@@ -0,0 +1,60 @@
+package rfc9839
+
+import "testing"
+
+func TestValidUtf8ReverseGood(t *testing.T) {
+	if !Scalars.ValidUtf8Reverse([]byte("hello, world")) {
+		t.Error("valid input reported as invalid")
+	}
+	if !Scalars.ValidUtf8Reverse(nil) {
+		t.Error("empty input reported as invalid")
+	}
+}
+
+func TestValidUtf8ReverseBad(t *testing.T) {
+	bad := append([]byte("hello"), 0xED, 0xBA, 0xAD) // trailing surrogate
+	if Scalars.ValidUtf8Reverse(bad) {
+		t.Error("invalid input reported as valid")
+	}
+}
+
+func TestLastInvalid(t *testing.T) {
+	bad := append([]byte("hello"), 0xFF)
+	off, r, ok := Scalars.LastInvalid(bad)
+	if !ok || off != len(bad)-1 || r != 0xFFFD {
+		t.Errorf("got (%d, %#x, %v), want (%d, 0xfffd, true)", off, r, ok, len(bad)-1)
+	}
+	if Scalars.ValidUtf8Reverse(bad[:off]) != true {
+		t.Error("bad[:off] should be the longest valid prefix")
+	}
+}
+
+func TestLastInvalidAllValid(t *testing.T) {
+	if _, _, ok := Scalars.LastInvalid([]byte("hello, world")); ok {
+		t.Error("valid input reported as invalid")
+	}
+}
+
+func TestLastInvalidDoesNotGuaranteeCleanPrefix(t *testing.T) {
+	// Two invalid bytes: LastInvalid only reports the one closest to the
+	// end and never looks further back, so the prefix before byteOffset
+	// isn't guaranteed clean even though the doc comment once claimed it
+	// was the "safe point to truncate at".
+	u := []byte{'a', 0xFF, 'b', 0xFF}
+	off, _, ok := Scalars.LastInvalid(u)
+	if !ok || off != 3 {
+		t.Fatalf("got (%d, %v), want (3, true)", off, ok)
+	}
+	if Scalars.ValidUtf8Reverse(u[:off]) {
+		t.Error("u[:off] still contains an earlier invalid byte and must not be reported as valid")
+	}
+}
+
+func TestLastInvalidOutsideSubset(t *testing.T) {
+	// \x01 is valid UTF-8 but outside XmlChars.
+	u := []byte("ok\x01")
+	off, r, ok := XmlChars.LastInvalid(u)
+	if !ok || off != 2 || r != 0x01 {
+		t.Errorf("got (%d, %#x, %v), want (2, 0x1, true)", off, r, ok)
+	}
+}
@@ -0,0 +1,99 @@
+package rfc9839
+
+import (
+	"errors"
+	"io"
+	"unicode/utf8"
+)
+
+// ErrTruncatedUTF8 is returned when a stream ends in the middle of a
+// multi-byte UTF-8 sequence that never gets completed.
+var ErrTruncatedUTF8 = errors.New("rfc9839: stream ended with a truncated UTF-8 sequence")
+
+// ErrInvalidUTF8 is returned when a stream's content is either malformed
+// UTF-8 or contains a rune outside the Subset.
+var ErrInvalidUTF8 = errors.New("rfc9839: invalid UTF-8 for subset")
+
+// streamChunkSize is how much we read from the underlying reader at a time.
+const streamChunkSize = 32 * 1024
+
+// NewValidator wraps r in an io.Reader that passes bytes through unchanged
+// but returns an error from Read as soon as it finds a byte sequence that
+// isn't valid UTF-8 in sub, without ever buffering more than one chunk plus
+// a few carried-over bytes. This lets callers validate arbitrarily large
+// input, e.g. via io.Copy, without loading it all into memory the way
+// ValidUtf8 requires.
+func (sub *Subset) NewValidator(r io.Reader) io.Reader {
+	return &validatingReader{src: r, sub: sub}
+}
+
+// ValidateStream reads r to completion and reports the first error found,
+// either a malformed/disallowed rune (ErrInvalidUTF8) or a truncated
+// trailing sequence (ErrTruncatedUTF8). It returns nil if r is exhausted
+// without either problem.
+func (sub *Subset) ValidateStream(r io.Reader) error {
+	_, err := io.Copy(io.Discard, sub.NewValidator(r))
+	return err
+}
+
+type validatingReader struct {
+	src   io.Reader
+	sub   *Subset
+	carry []byte // bytes held back because they might be an incomplete rune
+	ready []byte // validated bytes waiting to be copied out via Read
+	chunk []byte
+	err   error
+}
+
+func (v *validatingReader) Read(p []byte) (int, error) {
+	for len(v.ready) == 0 && v.err == nil {
+		if v.chunk == nil {
+			v.chunk = make([]byte, streamChunkSize)
+		}
+		n, rerr := v.src.Read(v.chunk)
+		if n > 0 {
+			data := append(v.carry, v.chunk[:n]...)
+			complete, tail := splitTrailingIncomplete(data)
+			if !isUTF8InSubset(complete, v.sub) {
+				v.err = ErrInvalidUTF8
+				return 0, v.err
+			}
+			v.ready = complete
+			v.carry = append([]byte(nil), tail...)
+		}
+		if rerr != nil {
+			if rerr == io.EOF && len(v.carry) > 0 {
+				rerr = ErrTruncatedUTF8
+			}
+			v.err = rerr
+		}
+	}
+	if len(v.ready) == 0 {
+		return 0, v.err
+	}
+	n := copy(p, v.ready)
+	v.ready = v.ready[n:]
+	return n, nil
+}
+
+// splitTrailingIncomplete splits data into a leading portion that consists
+// entirely of complete UTF-8 rune encodings and a trailing portion of at
+// most utf8.UTFMax-1 bytes that looks like the start of a rune which more
+// data, arriving in a later chunk, might complete.
+func splitTrailingIncomplete(data []byte) (complete, tail []byte) {
+	if len(data) == 0 {
+		return data, nil
+	}
+	start := len(data) - 1
+	limit := len(data) - utf8.UTFMax
+	for start > limit && start >= 0 && !utf8.RuneStart(data[start]) {
+		start--
+	}
+	if start < 0 {
+		start = 0
+	}
+	if utf8.FullRune(data[start:]) {
+		return data, nil
+	}
+	return data[:start], data[start:]
+}
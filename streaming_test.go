@@ -0,0 +1,84 @@
+package rfc9839
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestValidateStreamGood(t *testing.T) {
+	if err := Scalars.ValidateStream(strings.NewReader("hello, world")); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestValidateStreamBad(t *testing.T) {
+	bad := []byte{0xED, 0xBA, 0xAD} // U+DEAD, a surrogate
+	if err := Scalars.ValidateStream(bytes.NewReader(bad)); err != ErrInvalidUTF8 {
+		t.Errorf("got %v, want ErrInvalidUTF8", err)
+	}
+}
+
+func TestValidateStreamTruncated(t *testing.T) {
+	euro := "€" // 3-byte UTF-8 encoding
+	truncated := []byte(euro)[:2]
+	if err := Scalars.ValidateStream(bytes.NewReader(truncated)); err != ErrTruncatedUTF8 {
+		t.Errorf("got %v, want ErrTruncatedUTF8", err)
+	}
+}
+
+func TestNewValidatorPassesThrough(t *testing.T) {
+	want := strings.Repeat("hello € world ", 5000) // force multiple chunks
+	r := Scalars.NewValidator(strings.NewReader(want))
+	got, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(got) != want {
+		t.Errorf("got %d bytes, want %d", len(got), len(want))
+	}
+}
+
+func TestNewValidatorRuneSplitAcrossChunks(t *testing.T) {
+	// Force the multi-byte euro sign to straddle a chunk boundary by
+	// wrapping the reader so it only ever returns a few bytes at a time.
+	want := strings.Repeat("x", streamChunkSize-1) + "€"
+	r := Scalars.NewValidator(&oneByteReader{data: []byte(want)})
+	got, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(got) != want {
+		t.Errorf("rune split across chunks wasn't reassembled correctly")
+	}
+}
+
+// oneByteReader returns at most one byte per Read, to exercise the
+// incomplete-tail carry-over logic deterministically.
+type oneByteReader struct {
+	data []byte
+}
+
+func (r *oneByteReader) Read(p []byte) (int, error) {
+	if len(r.data) == 0 {
+		return 0, io.EOF
+	}
+	p[0] = r.data[0]
+	r.data = r.data[1:]
+	return 1, nil
+}
+
+func TestSplitTrailingIncomplete(t *testing.T) {
+	complete, tail := splitTrailingIncomplete([]byte("abc"))
+	if string(complete) != "abc" || len(tail) != 0 {
+		t.Errorf("got %q/%q, want \"abc\"/\"\"", complete, tail)
+	}
+
+	euro := []byte("€") // 0xE2 0x82 0xAC
+	data := append([]byte("ab"), euro[:2]...)
+	complete, tail = splitTrailingIncomplete(data)
+	if string(complete) != "ab" || len(tail) != 2 {
+		t.Errorf("got %q/%q, want \"ab\"/2 tail bytes", complete, tail)
+	}
+}
@@ -0,0 +1,58 @@
+package rfc9839
+
+import (
+	"unicode/utf8"
+
+	"golang.org/x/text/transform"
+)
+
+// Replacer returns a transform.Transformer that copies its input to its
+// output unchanged, except that every rune not in sub - including the
+// bytes of a malformed UTF-8 sequence - is replaced by replacement. It can
+// be used with transform.NewReader/NewWriter to turn this package from a
+// validator into a sanitizer.
+//
+// If replacement isn't itself a valid rune in sub - including if it's a
+// surrogate or otherwise outside the Unicode range, which utf8.ValidRune
+// rejects - U+FFFD is used instead, since the transformer must never emit
+// a rune that a caller validating its output against sub would reject.
+func (sub *Subset) Replacer(replacement rune) transform.Transformer {
+	if !utf8.ValidRune(replacement) || !subsetContains(sub, replacement) {
+		replacement = 0xFFFD
+	}
+	return &replacingTransformer{sub: sub, replacement: replacement}
+}
+
+type replacingTransformer struct {
+	sub         *Subset
+	replacement rune
+}
+
+func (t *replacingTransformer) Reset() {}
+
+func (t *replacingTransformer) Transform(dst, src []byte, atEOF bool) (nDst, nSrc int, err error) {
+	for nSrc < len(src) {
+		if !atEOF && !utf8.FullRune(src[nSrc:]) {
+			return nDst, nSrc, transform.ErrShortSrc
+		}
+		r, width := utf8.DecodeRune(src[nSrc:])
+		out := r
+		if (r == 0xFFFD && width == 1) || !subsetContains(t.sub, r) {
+			out = t.replacement
+		}
+		outWidth := utf8.RuneLen(out)
+		if outWidth < 0 {
+			// out should always be a valid rune by construction, but guard
+			// against RuneLen's -1 sentinel anyway rather than let a
+			// negative width corrupt nDst.
+			out, outWidth = utf8.RuneError, utf8.RuneLen(utf8.RuneError)
+		}
+		if nDst+outWidth > len(dst) {
+			return nDst, nSrc, transform.ErrShortDst
+		}
+		utf8.EncodeRune(dst[nDst:], out)
+		nDst += outWidth
+		nSrc += width
+	}
+	return nDst, nSrc, nil
+}
@@ -0,0 +1,108 @@
+package rfc9839
+
+import (
+	"bytes"
+	"testing"
+
+	"golang.org/x/text/transform"
+)
+
+func TestReplacerPassesGoodInput(t *testing.T) {
+	want := "hello, world"
+	got, _, err := transform.Bytes(Scalars.Replacer('?'), []byte(want))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(got) != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestReplacerReplacesDisallowedRune(t *testing.T) {
+	// U+0001, a control character, is outside XmlChars.
+	in := []byte("a\x01b")
+	got, _, err := transform.Bytes(XmlChars.Replacer('?'), in)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(got) != "a?b" {
+		t.Errorf("got %q, want %q", got, "a?b")
+	}
+}
+
+func TestReplacerReplacesMalformedUTF8(t *testing.T) {
+	in := []byte{'a', 0xFF, 'b'}
+	got, _, err := transform.Bytes(Scalars.Replacer('?'), in)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(got) != "a?b" {
+		t.Errorf("got %q, want %q", got, "a?b")
+	}
+}
+
+func TestReplacerFallsBackToFFFDForBadReplacement(t *testing.T) {
+	// 0xD800 is a surrogate: not a valid rune at all, so Replacer must
+	// fall back to U+FFFD rather than storing it as the replacement.
+	got, _, err := transform.Bytes(Scalars.Replacer(0xD800), []byte{'a', 0xFF, 'b'})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := "a�b"
+	if string(got) != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestReplacerFallsBackWhenReplacementOutsideSubset(t *testing.T) {
+	// '\x01' is not itself in XmlChars, so it can't be used as the
+	// replacement rune; Replacer must fall back to U+FFFD instead of
+	// emitting output that would itself fail validation.
+	got, _, err := transform.Bytes(XmlChars.Replacer('\x01'), []byte("a\x02b"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := "a�b"
+	if string(got) != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestReplacerShortDst(t *testing.T) {
+	tr := Scalars.Replacer('?')
+	dst := make([]byte, 1)
+	src := []byte("ab")
+	nDst, nSrc, err := tr.Transform(dst, src, true)
+	if err != transform.ErrShortDst {
+		t.Fatalf("got err %v, want ErrShortDst", err)
+	}
+	if nDst != 1 || nSrc != 1 {
+		t.Errorf("got (%d, %d), want (1, 1)", nDst, nSrc)
+	}
+}
+
+func TestReplacerShortSrc(t *testing.T) {
+	tr := Scalars.Replacer('?')
+	euro := []byte("€") // 3-byte sequence
+	dst := make([]byte, 16)
+	nDst, nSrc, err := tr.Transform(dst, euro[:2], false)
+	if err != transform.ErrShortSrc {
+		t.Fatalf("got err %v, want ErrShortSrc", err)
+	}
+	if nDst != 0 || nSrc != 0 {
+		t.Errorf("got (%d, %d), want (0, 0)", nDst, nSrc)
+	}
+}
+
+func TestReplacerLargeInputViaReader(t *testing.T) {
+	in := bytes.Repeat([]byte("ok\x01"), 10000)
+	r := transform.NewReader(bytes.NewReader(in), XmlChars.Replacer('?'))
+	var buf bytes.Buffer
+	if _, err := buf.ReadFrom(r); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := bytes.Repeat([]byte("ok?"), 10000)
+	if !bytes.Equal(buf.Bytes(), want) {
+		t.Errorf("large streamed replace produced wrong output")
+	}
+}
@@ -1,6 +1,7 @@
 package rfc9839
 
 import (
+	"sync"
 	"unicode/utf8"
 )
 
@@ -9,7 +10,19 @@ type runePair struct {
 	hi rune
 }
 
-type Subset struct{ pairs []runePair }
+type Subset struct {
+	pairs []runePair
+
+	// asciiBitmap and sortedPairs are derived lazily from pairs the first
+	// time the hot validation loops need them, so that Subsets built as
+	// plain struct literals (as Scalars, XmlChars and Assignables are,
+	// below) don't pay for them until used.
+	asciiOnce   sync.Once
+	asciiBitmap [2]uint64
+
+	sortedOnce  sync.Once
+	sortedPairs []runePair
+}
 
 func (sub *Subset) ValidRune(r rune) bool {
 	return subsetContains(sub, r)
@@ -85,6 +98,10 @@ func pairContains(pair runePair, r rune) bool {
 	return r >= pair.lo && r <= pair.hi
 }
 
+// subsetContains is the fallback linear scan, kept in "guessed traffic
+// order" so that it short-circuits quickly on the common case. It's still
+// used to answer single-rune questions (ValidRune) and to build the ASCII
+// bitmap and sorted-pairs caches below.
 func subsetContains(sub *Subset, r rune) bool {
 	for _, pair := range sub.pairs {
 		if pairContains(pair, r) {
@@ -94,16 +111,67 @@ func subsetContains(sub *Subset, r rune) bool {
 	return false
 }
 
+// ascii returns a 128-bit bitmap, one bit per ASCII byte value, saying
+// whether that byte is in sub. It's built once per Subset and is the fast
+// path for the ~95% ASCII case typical of JSON/XML workloads.
+func (sub *Subset) ascii() [2]uint64 {
+	sub.asciiOnce.Do(func() {
+		for b := rune(0); b < utf8.RuneSelf; b++ {
+			if subsetContains(sub, b) {
+				sub.asciiBitmap[b>>6] |= 1 << uint(b&63)
+			}
+		}
+	})
+	return sub.asciiBitmap
+}
+
+// sorted returns sub.pairs sorted by lo and coalesced for binary search,
+// built lazily on first use so that constructing a Subset stays cheap.
+func (sub *Subset) sorted() []runePair {
+	sub.sortedOnce.Do(func() {
+		sub.sortedPairs = coalesce(sub.pairs)
+	})
+	return sub.sortedPairs
+}
+
+// binarySearchContains replaces the O(k) linear scan with an O(log k)
+// search over a sorted, coalesced pair list, for the non-ASCII runes where
+// k (25 pairs for Assignables) starts to matter.
+func binarySearchContains(sorted []runePair, r rune) bool {
+	lo, hi := 0, len(sorted)
+	for lo < hi {
+		mid := (lo + hi) / 2
+		switch {
+		case r < sorted[mid].lo:
+			hi = mid
+		case r > sorted[mid].hi:
+			lo = mid + 1
+		default:
+			return true
+		}
+	}
+	return false
+}
+
 func isUTF8InSubset(u []byte, sub *Subset) bool {
+	bitmap := sub.ascii()
+	sorted := sub.sorted()
 	index := 0
 	for index < len(u) {
+		if b := u[index]; b < utf8.RuneSelf {
+			if bitmap[b>>6]&(1<<uint(b&63)) == 0 {
+				return false
+			}
+			index++
+			continue
+		}
 		r, width := utf8.DecodeRune(u[index:])
 		if r == 0xFFFD && width == 1 {
 			// this is how the utf8 pkg signals invalid UTF8 bytes, notably
 			// including surrogate values
 			return false
 		}
-		if !subsetContains(sub, r) {
+		if !binarySearchContains(sorted, r) {
 			return false
 		}
 		index += width
@@ -112,15 +180,24 @@ func isUTF8InSubset(u []byte, sub *Subset) bool {
 }
 
 func isStringInSubset(s string, sub *Subset) bool {
+	bitmap := sub.ascii()
+	sorted := sub.sorted()
 	index := 0
 	for index < len(s) {
+		if b := s[index]; b < utf8.RuneSelf {
+			if bitmap[b>>6]&(1<<uint(b&63)) == 0 {
+				return false
+			}
+			index++
+			continue
+		}
 		r, width := utf8.DecodeRuneInString(s[index:])
 		if r == 0xFFFD && width == 1 {
 			// this is how the utf8 pkg signals invalid UTF8 bytes, notably
 			// including surrogate values
 			return false
 		}
-		if !subsetContains(sub, r) {
+		if !binarySearchContains(sorted, r) {
 			return false
 		}
 		index += width
@@ -3,6 +3,7 @@ package rfc9839
 import (
 	"os"
 	"testing"
+	"unicode/utf8"
 )
 
 func BenchmarkValidUtf8(b *testing.B) {
@@ -43,3 +44,43 @@ func BenchmarkValidString(b *testing.B) {
 		XmlChars.ValidString(s)
 	}
 }
+
+// BenchmarkValidUtf8Previous exercises the pre-fast-path implementation
+// (DecodeRune plus a linear scan for every rune, ASCII included) so it can
+// be compared against BenchmarkValidUtf8 above on the same inputs.
+func BenchmarkValidUtf8Previous(b *testing.B) {
+	file, err := os.ReadFile("testdata/sample.txt")
+	if err != nil {
+		b.Error(err)
+	}
+
+	bytes := len(file)
+	b.SetBytes(int64(bytes))
+	b.ReportAllocs()
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		isUTF8InSubsetLinear(file, Assignables)
+		isUTF8InSubsetLinear(file, Scalars)
+		isUTF8InSubsetLinear(file, XmlChars)
+	}
+}
+
+// isUTF8InSubsetLinear is the pre-fast-path validation loop: DecodeRune
+// plus a linear pair scan for every rune, ASCII included. Kept only to
+// give BenchmarkValidUtf8Previous something to compare against.
+func isUTF8InSubsetLinear(u []byte, sub *Subset) bool {
+	index := 0
+	for index < len(u) {
+		r, width := utf8.DecodeRune(u[index:])
+		if r == 0xFFFD && width == 1 {
+			return false
+		}
+		if !subsetContains(sub, r) {
+			return false
+		}
+		index += width
+	}
+	return true
+}
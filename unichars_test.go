@@ -15,49 +15,51 @@ func TestEmpties(t *testing.T) {
 	var emptyU2 []byte = nil
 	var emptyS = ""
 
-	if !IsUTF8UnicodeScalars(emptyU1) {
+	if !Scalars.ValidUtf8(emptyU1) {
 		t.Error("zero-length []byte failure")
 	}
-	if !IsUTF8XmlChars(emptyU1) {
+	if !XmlChars.ValidUtf8(emptyU1) {
 		t.Error("zero-length []byte failure")
 	}
-	if !IsUTF8UnicodeAssignables(emptyU1) {
+	if !Assignables.ValidUtf8(emptyU1) {
 		t.Error("zero-length []byte failure")
 	}
 
-	if !IsUTF8UnicodeScalars(emptyU2) {
+	if !Scalars.ValidUtf8(emptyU2) {
 		t.Error("nil []byte failure")
 	}
-	if !IsUTF8XmlChars(emptyU2) {
+	if !XmlChars.ValidUtf8(emptyU2) {
 		t.Error("nil []byte failure")
 	}
-	if !IsUTF8UnicodeAssignables(emptyU2) {
+	if !Assignables.ValidUtf8(emptyU2) {
 		t.Error("nil []byte failure")
 	}
 
-	if !IsStringUnicodeScalars(emptyS) {
+	if !Scalars.ValidString(emptyS) {
 		t.Error("empty string failure")
 	}
-	if !IsStringXmlChars(emptyS) {
+	if !XmlChars.ValidString(emptyS) {
 		t.Error("empty string failure")
 	}
-	if !IsStringUnicodeAssignables(emptyS) {
+	if !Assignables.ValidString(emptyS) {
 		t.Error("empty string failure")
 	}
 }
 
 func TestScalars(t *testing.T) {
-	// Test that all runes in our unicodeScalars table are accepted
-	for _, r16 := range UnicodeScalars.R16 {
-		for r := r16.Lo; r <= r16.Hi; r += r16.Stride {
-			if !unicode.Is(UnicodeScalars, rune(r)) {
+	rt := Scalars.RangeTable()
+
+	// Test that all runes in our Scalars table are accepted
+	for _, r16 := range rt.R16 {
+		for r := rune(r16.Lo); r <= rune(r16.Hi); r += rune(r16.Stride) {
+			if !unicode.Is(rt, r) {
 				t.Errorf("%x should be unicode scalar", r)
 			}
 		}
 	}
-	for _, r32 := range UnicodeScalars.R32 {
-		for r := r32.Lo; r <= r32.Hi; r += r32.Stride {
-			if !unicode.Is(UnicodeScalars, rune(r)) {
+	for _, r32 := range rt.R32 {
+		for r := rune(r32.Lo); r <= rune(r32.Hi); r += rune(r32.Stride) {
+			if !unicode.Is(rt, r) {
 				t.Errorf("%x should be unicode scalar", r)
 			}
 		}
@@ -65,15 +67,15 @@ func TestScalars(t *testing.T) {
 
 	// Test that surrogate pairs are rejected
 	for _, r := range inverseScalars {
-		if unicode.Is(UnicodeScalars, r) {
+		if unicode.Is(rt, r) {
 			t.Errorf("%x should not be unicode scalar", r)
 		}
 	}
 
-	if unicode.Is(UnicodeScalars, -1) {
+	if unicode.Is(rt, -1) {
 		t.Error("-1 should not be scalar")
 	}
-	if unicode.Is(UnicodeScalars, 0x10FFFF+1) {
+	if unicode.Is(rt, 0x10FFFF+1) {
 		t.Error("0x10FFFF+1 should not be scalar")
 	}
 
@@ -82,10 +84,10 @@ func TestScalars(t *testing.T) {
 	bad := []byte{'a'}
 	bad = append(bad, badUTF8...)
 	bad = append(bad, 'z')
-	if IsUTF8UnicodeScalars(bad) {
+	if Scalars.ValidUtf8(bad) {
 		t.Error("accepted invalid UTF8")
 	}
-	if IsStringUnicodeScalars(string(bad)) {
+	if Scalars.ValidString(string(bad)) {
 		t.Error("accepted invalid UTF8")
 	}
 }
@@ -99,17 +101,19 @@ var inverseXML = []rune{
 }
 
 func TestXmlChars(t *testing.T) {
-	// Test that all runes in our xmlChars table are accepted
-	for _, r16 := range XmlChars.R16 {
-		for r := r16.Lo; r <= r16.Hi; r += r16.Stride {
-			if !unicode.Is(XmlChars, rune(r)) {
+	rt := XmlChars.RangeTable()
+
+	// Test that all runes in our XmlChars table are accepted
+	for _, r16 := range rt.R16 {
+		for r := rune(r16.Lo); r <= rune(r16.Hi); r += rune(r16.Stride) {
+			if !unicode.Is(rt, r) {
 				t.Errorf("%x should be XML", r)
 			}
 		}
 	}
-	for _, r32 := range XmlChars.R32 {
-		for r := r32.Lo; r <= r32.Hi; r += r32.Stride {
-			if !unicode.Is(XmlChars, rune(r)) {
+	for _, r32 := range rt.R32 {
+		for r := rune(r32.Lo); r <= rune(r32.Hi); r += rune(r32.Stride) {
+			if !unicode.Is(rt, r) {
 				t.Errorf("%x should be XML", r)
 			}
 		}
@@ -117,15 +121,15 @@ func TestXmlChars(t *testing.T) {
 
 	// Test that inverse ranges are rejected
 	for _, r := range inverseXML {
-		if unicode.Is(XmlChars, r) {
+		if unicode.Is(rt, r) {
 			t.Errorf("%x should not be XML", r)
 		}
 	}
 
-	if unicode.Is(XmlChars, -1) {
+	if unicode.Is(rt, -1) {
 		t.Error("-1 should not be scalar")
 	}
-	if unicode.Is(XmlChars, 0x10FFFF+1) {
+	if unicode.Is(rt, 0x10FFFF+1) {
 		t.Error("0x10FFFF+1 should not be scalar")
 	}
 
@@ -133,17 +137,17 @@ func TestXmlChars(t *testing.T) {
 	bad := []byte{'a'}
 	bad = append(bad, badUTF8...)
 	bad = append(bad, 'z')
-	if IsUTF8XmlChars(bad) {
+	if XmlChars.ValidUtf8(bad) {
 		t.Error("accepted invalid UTF8")
 	}
-	if IsStringXmlChars(string(bad)) {
+	if XmlChars.ValidString(string(bad)) {
 		t.Error("accepted invalid UTF8")
 	}
 
 	// Test good strings
 	goodS := []rune{}
 	goodU := []byte{}
-	for _, r16 := range XmlChars.R16 {
+	for _, r16 := range rt.R16 {
 		goodS = append(goodS, rune(r16.Lo), rune(r16.Hi))
 		loLen := utf8.RuneLen(rune(r16.Lo))
 		if loLen > 0 {
@@ -158,7 +162,7 @@ func TestXmlChars(t *testing.T) {
 			goodU = append(goodU, u...)
 		}
 	}
-	for _, r32 := range XmlChars.R32 {
+	for _, r32 := range rt.R32 {
 		goodS = append(goodS, rune(r32.Lo), rune(r32.Hi))
 		loLen := utf8.RuneLen(rune(r32.Lo))
 		if loLen > 0 {
@@ -174,10 +178,10 @@ func TestXmlChars(t *testing.T) {
 		}
 	}
 
-	if !IsStringXmlChars(string(goodS)) {
+	if !XmlChars.ValidString(string(goodS)) {
 		t.Error("good string rejected")
 	}
-	if !IsUTF8XmlChars(goodU) {
+	if !XmlChars.ValidUtf8(goodU) {
 		t.Error("good UTF8 rejected")
 	}
 
@@ -193,10 +197,10 @@ func TestXmlChars(t *testing.T) {
 			utf8.EncodeRune(u, r)
 			u = append(bad, u...)
 			u = append(u, 'z')
-			if IsUTF8XmlChars(u) {
+			if XmlChars.ValidUtf8(u) {
 				t.Errorf("accepted utf8 containing %x", r)
 			}
-			if IsStringXmlChars(string(u)) {
+			if XmlChars.ValidString(string(u)) {
 				t.Errorf("accepted utf8 containing %x", r)
 			}
 		}
@@ -230,17 +234,19 @@ var inverseAssignables = []rune{
 }
 
 func TestAssignables(t *testing.T) {
-	// Test that all runes in our unicodeAssignables table are accepted
-	for _, r16 := range UnicodeAssignables.R16 {
-		for r := r16.Lo; r <= r16.Hi; r += r16.Stride {
-			if !unicode.Is(UnicodeAssignables, rune(r)) {
+	rt := Assignables.RangeTable()
+
+	// Test that all runes in our Assignables table are accepted
+	for _, r16 := range rt.R16 {
+		for r := rune(r16.Lo); r <= rune(r16.Hi); r += rune(r16.Stride) {
+			if !unicode.Is(rt, r) {
 				t.Errorf("%x should be Assignable", r)
 			}
 		}
 	}
-	for _, r32 := range UnicodeAssignables.R32 {
-		for r := r32.Lo; r <= r32.Hi; r += r32.Stride {
-			if !unicode.Is(UnicodeAssignables, rune(r)) {
+	for _, r32 := range rt.R32 {
+		for r := rune(r32.Lo); r <= rune(r32.Hi); r += rune(r32.Stride) {
+			if !unicode.Is(rt, r) {
 				t.Errorf("%x should be Assignable", r)
 			}
 		}
@@ -248,15 +254,15 @@ func TestAssignables(t *testing.T) {
 
 	// Test that inverse ranges are rejected
 	for _, r := range inverseAssignables {
-		if unicode.Is(UnicodeAssignables, r) {
+		if unicode.Is(rt, r) {
 			t.Errorf("%x should not be Assignable", r)
 		}
 	}
 
-	if unicode.Is(UnicodeAssignables, -1) {
+	if unicode.Is(rt, -1) {
 		t.Error("-1 should not be scalar")
 	}
-	if unicode.Is(UnicodeAssignables, 0x10FFFF+1) {
+	if unicode.Is(rt, 0x10FFFF+1) {
 		t.Error("0x10FFFF+1 should not be scalar")
 	}
 
@@ -264,17 +270,17 @@ func TestAssignables(t *testing.T) {
 	bad := []byte{'a'}
 	bad = append(bad, badUTF8...)
 	bad = append(bad, 'z')
-	if IsUTF8UnicodeAssignables(bad) {
+	if Assignables.ValidUtf8(bad) {
 		t.Error("accepted invalid UTF8")
 	}
-	if IsStringUnicodeAssignables(string(bad)) {
+	if Assignables.ValidString(string(bad)) {
 		t.Error("accepted invalid UTF8")
 	}
 
 	// Test good strings
 	goodS := []rune{}
 	goodU := []byte{}
-	for _, r16 := range UnicodeAssignables.R16 {
+	for _, r16 := range rt.R16 {
 		goodS = append(goodS, rune(r16.Lo), rune(r16.Hi))
 		loLen := utf8.RuneLen(rune(r16.Lo))
 		if loLen > 0 {
@@ -289,7 +295,7 @@ func TestAssignables(t *testing.T) {
 			goodU = append(goodU, u...)
 		}
 	}
-	for _, r32 := range UnicodeAssignables.R32 {
+	for _, r32 := range rt.R32 {
 		goodS = append(goodS, rune(r32.Lo), rune(r32.Hi))
 		loLen := utf8.RuneLen(rune(r32.Lo))
 		if loLen > 0 {
@@ -305,10 +311,10 @@ func TestAssignables(t *testing.T) {
 		}
 	}
 
-	if !IsStringUnicodeAssignables(string(goodS)) {
+	if !Assignables.ValidString(string(goodS)) {
 		t.Error("good string rejected")
 	}
-	if !IsUTF8UnicodeAssignables(goodU) {
+	if !Assignables.ValidUtf8(goodU) {
 		t.Error("good UTF8 rejected")
 	}
 
@@ -324,10 +330,10 @@ func TestAssignables(t *testing.T) {
 			utf8.EncodeRune(u, r)
 			u = append(bad, u...)
 			u = append(u, 'z')
-			if IsUTF8UnicodeAssignables(u) {
+			if Assignables.ValidUtf8(u) {
 				t.Errorf("accepted utf8 containing %x", r)
 			}
-			if IsStringUnicodeAssignables(string(u)) {
+			if Assignables.ValidString(string(u)) {
 				t.Errorf("accepted utf8 containing %x", r)
 			}
 		}